@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldInfo is the precomputed metadata mapToStruct needs to assign an
+// incoming key to a struct field: its index path (more than one element
+// deep for a squashed embedded field) and its reflect.Type, used by
+// FieldByIndex and convertValue respectively.
+type fieldInfo struct {
+	Index []int
+	Type  reflect.Type
+}
+
+// fieldCacheKey distinguishes cached field maps by the options that affect
+// how they were built, since the same struct type can resolve keys
+// differently depending on TagName and Squash.
+type fieldCacheKey struct {
+	typ     reflect.Type
+	tagName string
+	squash  bool
+}
+
+// fieldCache memoizes, per struct type and relevant Options, the
+// map[string]fieldInfo mapToStruct needs to resolve an incoming key. This
+// avoids calling FieldByName and re-walking every field's tags on every
+// call to MapToStruct, the same optimization k8s apimachinery's converter
+// gets from its fieldsCache/structField types.
+var fieldCache sync.Map // map[fieldCacheKey]map[string]fieldInfo
+
+// fieldsFor returns the field map for t under opts, building and caching
+// it on first use.
+func fieldsFor(t reflect.Type, opts Options) map[string]fieldInfo {
+	key := fieldCacheKey{typ: t, tagName: opts.TagName, squash: opts.Squash}
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.(map[string]fieldInfo)
+	}
+	fields := buildFields(t, opts, nil)
+	actual, _ := fieldCache.LoadOrStore(key, fields)
+	return actual.(map[string]fieldInfo)
+}
+
+func buildFields(t reflect.Type, opts Options, indexPrefix []int) map[string]fieldInfo {
+	fields := make(map[string]fieldInfo, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue // unexported
+		}
+
+		index := make([]int, len(indexPrefix), len(indexPrefix)+1)
+		copy(index, indexPrefix)
+		index = append(index, i)
+
+		if sf.Anonymous && opts.Squash && sf.Type.Kind() == reflect.Struct {
+			for name, info := range buildFields(sf.Type, opts, index) {
+				if _, exists := fields[name]; !exists {
+					fields[name] = info
+				}
+			}
+			continue
+		}
+
+		name, skip := resolvedFieldName(sf, opts.TagName)
+		if skip {
+			continue
+		}
+		fields[name] = fieldInfo{Index: index, Type: sf.Type}
+	}
+	return fields
+}
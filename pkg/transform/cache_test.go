@@ -0,0 +1,48 @@
+package transform
+
+import (
+	"reflect"
+	"testing"
+)
+
+type BenchCar struct {
+	Brand    string
+	Year     int
+	Used     bool
+	Price    float64
+	Document string
+}
+
+func TestFieldsFor_Cached(t *testing.T) {
+	opts := DefaultOptions()
+	first := fieldsFor(reflect.TypeOf(BenchCar{}), opts)
+	second := fieldsFor(reflect.TypeOf(BenchCar{}), opts)
+	if len(first) != len(second) {
+		t.Fatalf("expected equal field maps, got %d and %d entries", len(first), len(second))
+	}
+	if reflect.ValueOf(first).Pointer() != reflect.ValueOf(second).Pointer() {
+		t.Fatal("expected the second call to return the cached field map")
+	}
+	info, ok := second["Brand"]
+	if !ok || len(info.Index) != 1 || info.Index[0] != 0 {
+		t.Fatalf("unexpected field info for Brand: %+v", info)
+	}
+}
+
+func BenchmarkMapToStruct(b *testing.B) {
+	data := map[string]interface{}{
+		"Brand":    "Toyota",
+		"Year":     2020,
+		"Used":     true,
+		"Price":    100000.50,
+		"Document": "123",
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var car BenchCar
+		if err := MapToStruct(data, &car); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
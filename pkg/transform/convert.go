@@ -0,0 +1,171 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// convertValue converts value into a reflect.Value assignable to targetType.
+// It covers the scalar ConvertX helpers in unmarshal.go plus the composite kinds
+// MapToStruct needs to support: nested structs (via a recursive MapToStruct
+// call), pointers (auto-allocated with reflect.New), maps with a string key,
+// and slices, typed or []interface{}. This mirrors the recursive
+// fromUnstructured pattern used by k8s apimachinery's converter.
+//
+// When opts.WeaklyTypedInput is false, scalar conversions require the
+// incoming value's Go type to already match targetType's kind instead of
+// coercing between strings, numbers, and bools.
+//
+// A nil value destined for a pointer field sets the field to its zero
+// (nil) value instead of falling into the scalar/struct conversion below,
+// matching encoding/json and mapstructure's null semantics for optional
+// fields.
+//
+// existing is the field's current value, used to seed nested struct/
+// pointer-to-struct conversions so that a partial map only overwrites the
+// keys it mentions instead of discarding the rest of the struct, the same
+// merge semantics json.Unmarshal applies to an already-populated struct.
+// It may be the zero Value when there's nothing to merge into (e.g. a map
+// or slice element), in which case the nested conversion starts fresh.
+func convertValue(value interface{}, targetType reflect.Type, opts Options, existing reflect.Value) (reflect.Value, error) {
+	kind := targetType.Kind()
+	if value == nil && kind == reflect.Ptr {
+		return reflect.Zero(targetType), nil
+	}
+
+	if v, handled, err := convertViaHook(value, targetType, opts); handled {
+		return v, err
+	}
+
+	if !opts.WeaklyTypedInput {
+		if err := checkStrictKind(value, kind); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	switch kind {
+	case reflect.String:
+		v, err := ConvertString(value)
+		return reflect.ValueOf(v), err
+	case reflect.Int:
+		v, err := ConvertInt(value)
+		return reflect.ValueOf(v), err
+	case reflect.Int8:
+		v, err := ConvertInt8(value)
+		return reflect.ValueOf(v), err
+	case reflect.Int16:
+		v, err := ConvertInt16(value)
+		return reflect.ValueOf(v), err
+	case reflect.Int32:
+		v, err := ConvertInt32(value)
+		return reflect.ValueOf(v), err
+	case reflect.Int64:
+		v, err := ConvertInt64(value)
+		return reflect.ValueOf(v), err
+	case reflect.Uint:
+		v, err := ConvertUint(value)
+		return reflect.ValueOf(v), err
+	case reflect.Uint8:
+		v, err := ConvertUint8(value)
+		return reflect.ValueOf(v), err
+	case reflect.Uint16:
+		v, err := ConvertUint16(value)
+		return reflect.ValueOf(v), err
+	case reflect.Uint32:
+		v, err := ConvertUint32(value)
+		return reflect.ValueOf(v), err
+	case reflect.Uint64:
+		v, err := ConvertUint64(value)
+		return reflect.ValueOf(v), err
+	case reflect.Float32:
+		v, err := ConvertFloat32(value)
+		return reflect.ValueOf(v), err
+	case reflect.Float64:
+		v, err := ConvertFloat64(value)
+		return reflect.ValueOf(v), err
+	case reflect.Bool:
+		v, err := ConvertBool(value)
+		return reflect.ValueOf(v), err
+	case reflect.Struct:
+		return convertStruct(value, targetType, opts, existing)
+	case reflect.Ptr:
+		return convertPtr(value, targetType, opts, existing)
+	case reflect.Map:
+		return convertMap(value, targetType, opts)
+	case reflect.Slice:
+		return convertSlice(value, targetType, opts)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported field type: %s", targetType)
+	}
+}
+
+func convertStruct(value interface{}, targetType reflect.Type, opts Options, existing reflect.Value) (reflect.Value, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot convert %T to struct %s", value, targetType)
+	}
+	newVal := reflect.New(targetType)
+	if existing.IsValid() {
+		newVal.Elem().Set(existing)
+	}
+	if err := mapToStruct(m, newVal.Interface(), opts); err != nil {
+		return reflect.Value{}, err
+	}
+	return newVal.Elem(), nil
+}
+
+func convertPtr(value interface{}, targetType reflect.Type, opts Options, existing reflect.Value) (reflect.Value, error) {
+	var existingElem reflect.Value
+	if existing.IsValid() && !existing.IsNil() {
+		existingElem = existing.Elem()
+	}
+	elemValue, err := convertValue(value, targetType.Elem(), opts, existingElem)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	ptr := reflect.New(targetType.Elem())
+	ptr.Elem().Set(elemValue)
+	return ptr, nil
+}
+
+func convertMap(value interface{}, targetType reflect.Type, opts Options) (reflect.Value, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot convert %T to map %s", value, targetType)
+	}
+	if targetType.Key().Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf("unsupported map key type: %s", targetType.Key())
+	}
+	newMap := reflect.MakeMapWithSize(targetType, len(m))
+	for k, v := range m {
+		elemValue, err := convertValue(v, targetType.Elem(), opts, reflect.Value{})
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %q: %w", k, err)
+		}
+		newMap.SetMapIndex(reflect.ValueOf(k).Convert(targetType.Key()), elemValue)
+	}
+	return newMap, nil
+}
+
+func convertSlice(value interface{}, targetType reflect.Type, opts Options) (reflect.Value, error) {
+	if targetType.Elem().Kind() == reflect.Interface {
+		v, err := ConvertSlice(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(v), nil
+	}
+	s, ok := value.([]interface{})
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot convert %T to slice %s", value, targetType)
+	}
+	newSlice := reflect.MakeSlice(targetType, 0, len(s))
+	for i, item := range s {
+		elemValue, err := convertValue(item, targetType.Elem(), opts, reflect.Value{})
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("index %d: %w", i, err)
+		}
+		newSlice = reflect.Append(newSlice, elemValue)
+	}
+	return newSlice, nil
+}
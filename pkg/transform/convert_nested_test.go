@@ -0,0 +1,147 @@
+package transform
+
+import "testing"
+
+type Engine struct {
+	Cylinders int
+	Turbo     bool
+}
+
+type Owner struct {
+	Name string
+}
+
+type Garage struct {
+	Engine   Engine
+	Owner    *Owner
+	Tags     []string
+	Mileage  map[string]int
+	Siblings []Engine
+}
+
+func TestMapToStruct_NestedStruct(t *testing.T) {
+	data := map[string]interface{}{
+		"Engine": map[string]interface{}{
+			"Cylinders": 4,
+			"Turbo":     true,
+		},
+	}
+	var g Garage
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Engine.Cylinders != 4 || !g.Engine.Turbo {
+		t.Fatalf("nested struct not populated correctly: %+v", g.Engine)
+	}
+}
+
+func TestMapToStruct_NestedStruct_MergesExistingFields(t *testing.T) {
+	data := map[string]interface{}{
+		"Engine": map[string]interface{}{
+			"Turbo": false,
+		},
+	}
+	g := Garage{Engine: Engine{Cylinders: 8, Turbo: true}}
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Engine.Cylinders != 8 || g.Engine.Turbo {
+		t.Fatalf("expected partial update to merge with existing fields, got: %+v", g.Engine)
+	}
+}
+
+func TestMapToStruct_Pointer(t *testing.T) {
+	data := map[string]interface{}{
+		"Owner": map[string]interface{}{
+			"Name": "Alice",
+		},
+	}
+	var g Garage
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Owner == nil || g.Owner.Name != "Alice" {
+		t.Fatalf("pointer field not populated correctly: %+v", g.Owner)
+	}
+}
+
+func TestMapToStruct_PointerNil(t *testing.T) {
+	data := map[string]interface{}{
+		"Owner": nil,
+	}
+	g := Garage{Owner: &Owner{Name: "Alice"}}
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Owner != nil {
+		t.Fatalf("expected nil Owner to leave the pointer nil, got: %+v", g.Owner)
+	}
+}
+
+func TestMapToStruct_PointerScalarNil(t *testing.T) {
+	data := map[string]interface{}{"Nickname": nil}
+	s := struct{ Nickname *string }{Nickname: new(string)}
+	if err := MapToStruct(data, &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Nickname != nil {
+		t.Fatalf("expected nil Nickname to leave the pointer nil, got: %v", *s.Nickname)
+	}
+}
+
+func TestMapToStruct_PointerToStruct_MergesExistingFields(t *testing.T) {
+	data := map[string]interface{}{
+		"Owner": map[string]interface{}{},
+	}
+	g := Garage{Owner: &Owner{Name: "Alice"}}
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Owner == nil || g.Owner.Name != "Alice" {
+		t.Fatalf("expected existing pointer fields to survive an empty update, got: %+v", g.Owner)
+	}
+}
+
+func TestMapToStruct_TypedSlice(t *testing.T) {
+	data := map[string]interface{}{
+		"Tags": []interface{}{"a", "b", "c"},
+	}
+	var g Garage
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Tags) != 3 || g.Tags[1] != "b" {
+		t.Fatalf("typed slice not populated correctly: %+v", g.Tags)
+	}
+}
+
+func TestMapToStruct_SliceOfStructs(t *testing.T) {
+	data := map[string]interface{}{
+		"Siblings": []interface{}{
+			map[string]interface{}{"Cylinders": 6, "Turbo": false},
+		},
+	}
+	var g Garage
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Siblings) != 1 || g.Siblings[0].Cylinders != 6 {
+		t.Fatalf("slice of structs not populated correctly: %+v", g.Siblings)
+	}
+}
+
+func TestMapToStruct_Map(t *testing.T) {
+	data := map[string]interface{}{
+		"Mileage": map[string]interface{}{
+			"2020": 12000,
+			"2021": 9000,
+		},
+	}
+	var g Garage
+	if err := MapToStruct(data, &g); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Mileage["2020"] != 12000 || g.Mileage["2021"] != 9000 {
+		t.Fatalf("map field not populated correctly: %+v", g.Mileage)
+	}
+}
@@ -0,0 +1,64 @@
+package transform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertInt8_Overflow(t *testing.T) {
+	_, err := ConvertInt8(float64(200))
+	if err == nil {
+		t.Fatal("expected overflow error, got nil")
+	}
+}
+
+func TestConvertInt8_ValidRange(t *testing.T) {
+	v, err := ConvertInt8(float64(120))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 120 {
+		t.Fatalf("expected 120, got %v", v)
+	}
+}
+
+func TestConvertInt64_FractionalFloat(t *testing.T) {
+	_, err := ConvertInt64(float64(1.5))
+	if err == nil {
+		t.Fatal("expected fractional part error, got nil")
+	}
+}
+
+func TestConvertUint8_Negative(t *testing.T) {
+	_, err := ConvertUint8(float64(-1))
+	if err == nil {
+		t.Fatal("expected negative value error, got nil")
+	}
+}
+
+func TestConvertUint32_FromString(t *testing.T) {
+	v, err := ConvertUint32("4000000000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 4000000000 {
+		t.Fatalf("expected 4000000000, got %v", v)
+	}
+}
+
+func TestConvertFloat32_Overflow(t *testing.T) {
+	_, err := ConvertFloat32(float64(1e40))
+	if err == nil {
+		t.Fatal("expected overflow error, got nil")
+	}
+}
+
+func TestConvertInt64_JSONNumber(t *testing.T) {
+	v, err := ConvertInt64(json.Number("42"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 42 {
+		t.Fatalf("expected 42, got %v", v)
+	}
+}
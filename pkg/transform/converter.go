@@ -0,0 +1,194 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Unmarshaler lets a type define its own coercion from the raw decoded
+// value (string, float64, etc.), the same way encoding/json respects
+// json.Unmarshaler. MapToStruct delegates to it for any field whose type
+// implements it, taking priority over the registered converters below.
+type Unmarshaler interface {
+	UnmarshalTransform(value interface{}) error
+}
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// ConverterFunc coerces a raw decoded value into target, for a type that
+// doesn't implement Unmarshaler itself. See RegisterConverter.
+type ConverterFunc func(value interface{}) (interface{}, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[reflect.Type]ConverterFunc{}
+)
+
+func init() {
+	RegisterConverter(reflect.TypeOf(time.Time{}), convertTimeValue)
+	RegisterConverter(reflect.TypeOf(time.Duration(0)), convertDurationValue)
+}
+
+// RegisterConverter registers fn as the conversion MapToStruct uses
+// whenever it encounters a field of type target that doesn't implement
+// Unmarshaler itself. It lets callers plug in coercions for third-party
+// types they don't own, such as uuid.UUID or decimal.Decimal. Pass
+// Options.Converters instead to scope an override to a single call.
+func RegisterConverter(target reflect.Type, fn ConverterFunc) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[target] = fn
+}
+
+func lookupConverter(target reflect.Type, opts Options) (ConverterFunc, bool) {
+	if opts.Converters != nil {
+		if fn, ok := opts.Converters[target]; ok {
+			return fn, true
+		}
+	}
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[target]
+	return fn, ok
+}
+
+// convertViaHook gives Unmarshaler and the converter registry first crack
+// at value before convertValue falls back to its built-in kind switch.
+// handled is false when neither applies, so the caller should proceed.
+func convertViaHook(value interface{}, targetType reflect.Type, opts Options) (result reflect.Value, handled bool, err error) {
+	if reflect.PtrTo(targetType).Implements(unmarshalerType) {
+		ptr := reflect.New(targetType)
+		if err := ptr.Interface().(Unmarshaler).UnmarshalTransform(value); err != nil {
+			return reflect.Value{}, true, err
+		}
+		return ptr.Elem(), true, nil
+	}
+
+	if fn, ok := lookupConverter(targetType, opts); ok {
+		converted, err := fn(value)
+		if err != nil {
+			return reflect.Value{}, true, err
+		}
+		rv := reflect.ValueOf(converted)
+		if !rv.Type().AssignableTo(targetType) {
+			return reflect.Value{}, true, fmt.Errorf("converter for %s returned incompatible type %s", targetType, rv.Type())
+		}
+		return rv, true, nil
+	}
+
+	return reflect.Value{}, false, nil
+}
+
+// convertTimeValue is the built-in time.Time converter: RFC3339 strings or
+// a Unix-epoch number of seconds. Numeric coercion is delegated to
+// convertToInt64 so json.Number is accepted here the same as everywhere
+// else in the package.
+func convertTimeValue(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return time.Parse(time.RFC3339, s)
+	}
+	seconds, err := convertToInt64(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %T to time.Time: %w", value, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// convertDurationValue is the built-in time.Duration converter: Go
+// duration strings ("1h30m") or a number of nanoseconds. Numeric coercion
+// is delegated to convertToInt64 so json.Number is accepted here the same
+// as everywhere else in the package.
+func convertDurationValue(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return time.ParseDuration(s)
+	}
+	nanos, err := convertToInt64(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %T to time.Duration: %w", value, err)
+	}
+	return time.Duration(nanos), nil
+}
+
+// Marshaler lets a type define its own flattening into the raw
+// map/slice/scalar representation Marshal produces, the mirror image of
+// Unmarshaler. MarshalTransform takes priority over the registered
+// marshal converters below.
+type Marshaler interface {
+	MarshalTransform() (interface{}, error)
+}
+
+var marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+
+// MarshalConverterFunc flattens a value of a registered type into the raw
+// representation Marshal emits for it. See RegisterMarshalConverter.
+type MarshalConverterFunc func(value interface{}) (interface{}, error)
+
+var (
+	marshalConvertersMu sync.RWMutex
+	marshalConverters   = map[reflect.Type]MarshalConverterFunc{}
+)
+
+func init() {
+	RegisterMarshalConverter(reflect.TypeOf(time.Time{}), marshalTimeValue)
+	RegisterMarshalConverter(reflect.TypeOf(time.Duration(0)), marshalDurationValue)
+}
+
+// RegisterMarshalConverter registers fn as the flattening Marshal uses
+// whenever it encounters a value of type target that doesn't implement
+// Marshaler itself, the mirror image of RegisterConverter. Pass
+// Options.MarshalConverters instead to scope an override to a single call.
+func RegisterMarshalConverter(target reflect.Type, fn MarshalConverterFunc) {
+	marshalConvertersMu.Lock()
+	defer marshalConvertersMu.Unlock()
+	marshalConverters[target] = fn
+}
+
+func lookupMarshalConverter(target reflect.Type, opts Options) (MarshalConverterFunc, bool) {
+	if opts.MarshalConverters != nil {
+		if fn, ok := opts.MarshalConverters[target]; ok {
+			return fn, true
+		}
+	}
+	marshalConvertersMu.RLock()
+	defer marshalConvertersMu.RUnlock()
+	fn, ok := marshalConverters[target]
+	return fn, ok
+}
+
+// marshalViaHook gives Marshaler and the marshal converter registry first
+// crack at v before marshalValue falls back to its built-in kind switch,
+// the mirror image of convertViaHook. handled is false when neither
+// applies, so the caller should proceed.
+func marshalViaHook(v reflect.Value, opts Options) (result interface{}, handled bool, err error) {
+	if v.Type().Implements(marshalerType) {
+		out, err := v.Interface().(Marshaler).MarshalTransform()
+		return out, true, err
+	}
+	if reflect.PtrTo(v.Type()).Implements(marshalerType) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		out, err := ptr.Interface().(Marshaler).MarshalTransform()
+		return out, true, err
+	}
+
+	if fn, ok := lookupMarshalConverter(v.Type(), opts); ok {
+		out, err := fn(v.Interface())
+		return out, true, err
+	}
+
+	return nil, false, nil
+}
+
+// marshalTimeValue is the built-in time.Time converter: the mirror image
+// of convertTimeValue, emitting an RFC3339 string.
+func marshalTimeValue(value interface{}) (interface{}, error) {
+	return value.(time.Time).Format(time.RFC3339), nil
+}
+
+// marshalDurationValue is the built-in time.Duration converter: the
+// mirror image of convertDurationValue, emitting a Go duration string.
+func marshalDurationValue(value interface{}) (interface{}, error) {
+	return value.(time.Duration).String(), nil
+}
@@ -0,0 +1,141 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type CelsiusTemp struct {
+	Degrees float64
+}
+
+func (c *CelsiusTemp) UnmarshalTransform(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("expected a string like \"21C\", got %T", value)
+	}
+	var degrees float64
+	if _, err := fmt.Sscanf(s, "%fC", &degrees); err != nil {
+		return err
+	}
+	c.Degrees = degrees
+	return nil
+}
+
+type Trip struct {
+	StartedAt time.Time
+	Duration  time.Duration
+	Outside   CelsiusTemp
+}
+
+func TestMapToStruct_UnmarshalerField(t *testing.T) {
+	data := map[string]interface{}{"Outside": "21C"}
+	var trip Trip
+	if err := MapToStruct(data, &trip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trip.Outside.Degrees != 21 {
+		t.Fatalf("expected 21 degrees, got %v", trip.Outside.Degrees)
+	}
+}
+
+func TestMapToStruct_TimeRFC3339(t *testing.T) {
+	data := map[string]interface{}{"StartedAt": "2024-01-02T15:04:05Z"}
+	var trip Trip
+	if err := MapToStruct(data, &trip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := time.Parse(time.RFC3339, "2024-01-02T15:04:05Z")
+	if !trip.StartedAt.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, trip.StartedAt)
+	}
+}
+
+func TestMapToStruct_TimeUnixEpoch(t *testing.T) {
+	data := map[string]interface{}{"StartedAt": float64(1700000000)}
+	var trip Trip
+	if err := MapToStruct(data, &trip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trip.StartedAt.Unix() != 1700000000 {
+		t.Fatalf("expected unix time 1700000000, got %v", trip.StartedAt.Unix())
+	}
+}
+
+func TestMapToStruct_TimeJSONNumber(t *testing.T) {
+	data := map[string]interface{}{"StartedAt": json.Number("1700000000")}
+	var trip Trip
+	if err := MapToStruct(data, &trip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trip.StartedAt.Unix() != 1700000000 {
+		t.Fatalf("expected unix time 1700000000, got %v", trip.StartedAt.Unix())
+	}
+}
+
+func TestMapToStruct_DurationString(t *testing.T) {
+	data := map[string]interface{}{"Duration": "1h30m"}
+	var trip Trip
+	if err := MapToStruct(data, &trip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trip.Duration != 90*time.Minute {
+		t.Fatalf("expected 90m, got %v", trip.Duration)
+	}
+}
+
+func (c *CelsiusTemp) MarshalTransform() (interface{}, error) {
+	return fmt.Sprintf("%vC", c.Degrees), nil
+}
+
+func TestMarshal_MarshalerField(t *testing.T) {
+	trip := Trip{Outside: CelsiusTemp{Degrees: 21}}
+	m, err := Marshal(trip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["Outside"] != "21C" {
+		t.Fatalf("expected \"21C\", got %v", m["Outside"])
+	}
+}
+
+type Temperature struct {
+	Kelvin float64
+}
+
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(Temperature{}), func(value interface{}) (interface{}, error) {
+		celsius, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected a float64, got %T", value)
+		}
+		return Temperature{Kelvin: celsius + 273.15}, nil
+	})
+
+	data := map[string]interface{}{"Reading": float64(0)}
+	var s struct{ Reading Temperature }
+	if err := MapToStruct(data, &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Reading.Kelvin != 273.15 {
+		t.Fatalf("expected 273.15K, got %v", s.Reading.Kelvin)
+	}
+}
+
+func TestRegisterMarshalConverter(t *testing.T) {
+	RegisterMarshalConverter(reflect.TypeOf(Temperature{}), func(value interface{}) (interface{}, error) {
+		return value.(Temperature).Kelvin - 273.15, nil
+	})
+
+	s := struct{ Reading Temperature }{Reading: Temperature{Kelvin: 273.15}}
+	m, err := Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["Reading"] != 0.0 {
+		t.Fatalf("expected 0 celsius, got %v", m["Reading"])
+	}
+}
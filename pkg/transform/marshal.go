@@ -0,0 +1,178 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// Marshal converts a struct (or pointer to struct) into a
+// map[string]interface{}, the mirror image of MapToStruct. It honors the
+// same json tags, "transform" tag override, and Options.Squash that
+// MapToStruct uses to resolve incoming keys, so struct -> map -> struct
+// round-trips through this package alone. This is analogous to the
+// UnstructuredConverter.ToUnstructured half of k8s apimachinery's
+// converter interface.
+func Marshal(obj interface{}) (map[string]interface{}, error) {
+	return MarshalWithOptions(obj, DefaultOptions())
+}
+
+// MarshalWithOptions behaves like Marshal but lets the caller customize
+// tag resolution through opts.
+func MarshalWithOptions(obj interface{}, opts Options) (map[string]interface{}, error) {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("cannot marshal a nil pointer")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("obj should be a struct or a pointer to a struct")
+	}
+	return marshalStruct(v, opts)
+}
+
+// MarshalSlice marshals each element of a slice of structs (or pointers to
+// structs) into a map, mirroring UnmarshalSlice.
+func MarshalSlice(obj interface{}) ([]map[string]interface{}, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("obj should be a slice")
+	}
+	result := make([]map[string]interface{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		m, err := Marshal(v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		result[i] = m
+	}
+	return result, nil
+}
+
+func marshalStruct(v reflect.Value, opts Options) (map[string]interface{}, error) {
+	t := v.Type()
+	result := make(map[string]interface{}, t.NumField())
+
+	var multiErr error
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue // unexported
+		}
+		fv := v.Field(i)
+
+		if sf.Anonymous && opts.Squash && sf.Type.Kind() == reflect.Struct {
+			nested, err := marshalStruct(fv, opts)
+			if err != nil {
+				multiErr = multierr.Append(multiErr, err)
+				continue
+			}
+			for name, val := range nested {
+				if _, exists := result[name]; !exists {
+					result[name] = val
+				}
+			}
+			continue
+		}
+
+		name, omitEmpty := marshalFieldName(sf, opts)
+		if name == "-" {
+			continue
+		}
+		if omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		val, err := marshalValue(fv, opts)
+		if err != nil {
+			multiErr = multierr.Append(multiErr, &FieldError{field: name, err: err, fieldAffected: true})
+			continue
+		}
+		result[name] = val
+	}
+
+	return result, multiErr
+}
+
+func marshalValue(v reflect.Value, opts Options) (interface{}, error) {
+	if val, handled, err := marshalViaHook(v, opts); handled {
+		return val, err
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return marshalValue(v.Elem(), opts)
+	case reflect.Struct:
+		return marshalStruct(v, opts)
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			val, err := marshalValue(iter.Value(), opts)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprintf("%v", iter.Key().Interface())] = val
+		}
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		s := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			val, err := marshalValue(v.Index(i), opts)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = val
+		}
+		return s, nil
+	default:
+		return v.Interface(), nil
+	}
+}
+
+// marshalFieldName resolves the output key for sf via resolvedFieldName,
+// plus the omitempty flag carried by opts.TagName (e.g.
+// `json:"name,omitempty"`); name is "-" when the field should be excluded.
+func marshalFieldName(sf reflect.StructField, opts Options) (name string, omitEmpty bool) {
+	name, skip := resolvedFieldName(sf, opts.TagName)
+	if skip {
+		return "-", false
+	}
+	if opts.TagName != "" {
+		if raw, ok := sf.Tag.Lookup(opts.TagName); ok {
+			parts := strings.Split(raw, ",")
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitEmpty = true
+				}
+			}
+		}
+	}
+	return name, omitEmpty
+}
+
+// isEmptyValue mirrors encoding/json's definition of "empty" for omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
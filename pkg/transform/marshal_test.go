@@ -0,0 +1,127 @@
+package transform
+
+import (
+	"testing"
+	"time"
+)
+
+type MarshalCar struct {
+	Brand   string `json:"brand"`
+	Year    int    `json:"year,omitempty"`
+	Engine  Engine `json:"engine"`
+	Tags    []string
+	Private string `json:"-"`
+}
+
+func TestMarshal_Basic(t *testing.T) {
+	car := MarshalCar{
+		Brand:   "Toyota",
+		Year:    2020,
+		Engine:  Engine{Cylinders: 4, Turbo: true},
+		Tags:    []string{"suv", "hybrid"},
+		Private: "secret",
+	}
+
+	m, err := Marshal(car)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["brand"] != "Toyota" || m["year"] != 2020 {
+		t.Fatalf("unexpected marshaled fields: %+v", m)
+	}
+	if _, ok := m["Private"]; ok {
+		t.Fatalf("expected Private to be excluded by the '-' tag: %+v", m)
+	}
+	engine, ok := m["engine"].(map[string]interface{})
+	if !ok || engine["Cylinders"] != 4 {
+		t.Fatalf("nested struct not marshaled correctly: %+v", m["engine"])
+	}
+	tags, ok := m["Tags"].([]interface{})
+	if !ok || len(tags) != 2 || tags[0] != "suv" {
+		t.Fatalf("slice not marshaled correctly: %+v", m["Tags"])
+	}
+}
+
+func TestMarshal_OmitEmpty(t *testing.T) {
+	car := MarshalCar{Brand: "Toyota"}
+	m, err := Marshal(car)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := m["year"]; ok {
+		t.Fatalf("expected zero-value 'year' to be omitted, got: %+v", m)
+	}
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	original := MarshalCar{
+		Brand:  "Toyota",
+		Year:   2020,
+		Engine: Engine{Cylinders: 6, Turbo: false},
+		Tags:   []string{"sedan"},
+	}
+
+	m, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling: %v", err)
+	}
+
+	var roundTripped MarshalCar
+	if err := MapToStruct(m, &roundTripped); err != nil {
+		t.Fatalf("unexpected error unmarshaling: %v", err)
+	}
+	if roundTripped.Brand != original.Brand || roundTripped.Year != original.Year {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", roundTripped, original)
+	}
+	if roundTripped.Engine != original.Engine {
+		t.Fatalf("round-tripped nested struct mismatch: got %+v, want %+v", roundTripped.Engine, original.Engine)
+	}
+}
+
+type ScheduledRide struct {
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+func TestMarshal_RoundTrip_TimeField(t *testing.T) {
+	original := ScheduledRide{
+		StartedAt: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		Duration:  90 * time.Minute,
+	}
+
+	m, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling: %v", err)
+	}
+	if m["StartedAt"] != "2024-01-02T15:04:05Z" {
+		t.Fatalf("expected RFC3339 StartedAt, got: %v", m["StartedAt"])
+	}
+	if m["Duration"] != "1h30m0s" {
+		t.Fatalf("expected duration string, got: %v", m["Duration"])
+	}
+
+	var roundTripped ScheduledRide
+	if err := MapToStruct(m, &roundTripped); err != nil {
+		t.Fatalf("unexpected error unmarshaling: %v", err)
+	}
+	if !roundTripped.StartedAt.Equal(original.StartedAt) {
+		t.Fatalf("round-tripped StartedAt mismatch: got %v, want %v", roundTripped.StartedAt, original.StartedAt)
+	}
+	if roundTripped.Duration != original.Duration {
+		t.Fatalf("round-tripped Duration mismatch: got %v, want %v", roundTripped.Duration, original.Duration)
+	}
+}
+
+func TestMarshalSlice(t *testing.T) {
+	cars := []MarshalCar{
+		{Brand: "Toyota", Year: 2020},
+		{Brand: "Honda", Year: 2021},
+	}
+	maps, err := MarshalSlice(cars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(maps) != 2 || maps[0]["brand"] != "Toyota" || maps[1]["brand"] != "Honda" {
+		t.Fatalf("unexpected marshaled slice: %+v", maps)
+	}
+}
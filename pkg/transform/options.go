@@ -0,0 +1,120 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Options customizes how MapToStruct and Unmarshal resolve fields and
+// coerce values. The zero value is not ready to use; call DefaultOptions
+// to get the values Unmarshal/MapToStruct use when no Options are given.
+type Options struct {
+	// TagName is the struct tag consulted to resolve an incoming key to a
+	// field, in addition to the field's own "transform" tag. Defaults to
+	// "json", so `json:"name,omitempty"` tags are honored out of the box.
+	TagName string
+
+	// DisallowUnknownFields causes a FieldError to be reported for any
+	// incoming key that does not resolve to a struct field, instead of the
+	// key being silently ignored.
+	DisallowUnknownFields bool
+
+	// ErrorUnused is the mapstructure-style name for the same check as
+	// DisallowUnknownFields; set either (or both) to reject unknown keys.
+	ErrorUnused bool
+
+	// WeaklyTypedInput gates the string<->number<->bool coercions that
+	// ConvertString/ConvertInt/etc. perform. It defaults to true to match
+	// this package's historical behavior; set it to false to require the
+	// incoming value's Go type to already match the field's kind.
+	WeaklyTypedInput bool
+
+	// Squash inlines anonymous embedded struct fields, so a key matching a
+	// field promoted from an embedded struct resolves as if it were
+	// declared directly on the outer struct.
+	Squash bool
+
+	// Converters overrides, for this call only, the globally registered
+	// conversions a field's type is looked up in. See RegisterConverter.
+	Converters map[reflect.Type]ConverterFunc
+
+	// MarshalConverters overrides, for this call only, the globally
+	// registered flattenings a value's type is looked up in. See
+	// RegisterMarshalConverter.
+	MarshalConverters map[reflect.Type]MarshalConverterFunc
+}
+
+// DefaultOptions returns the Options Unmarshal and MapToStruct use when
+// called without an explicit Options value.
+func DefaultOptions() Options {
+	return Options{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+	}
+}
+
+// resolvedFieldName determines the key sf is addressed by, consulting the
+// "transform" tag and then tagName (e.g. "json"), before falling back to
+// sf.Name. skip reports that sf carries an explicit "-" in one of those
+// tags and should be excluded entirely, matching encoding/json semantics.
+func resolvedFieldName(sf reflect.StructField, tagName string) (name string, skip bool) {
+	if n, ok, excluded := tagFieldName(sf, "transform"); excluded {
+		return "", true
+	} else if ok {
+		return n, false
+	}
+	if tagName != "" {
+		if n, ok, excluded := tagFieldName(sf, tagName); excluded {
+			return "", true
+		} else if ok {
+			return n, false
+		}
+	}
+	return sf.Name, false
+}
+
+// tagFieldName returns the name portion of tag (before the first comma).
+// ok reports whether the tag was present with a usable name; excluded
+// reports an explicit "-" name, which callers should treat as "omit this
+// field" rather than "fall back to the Go field name".
+func tagFieldName(sf reflect.StructField, tag string) (name string, ok bool, excluded bool) {
+	raw, present := sf.Tag.Lookup(tag)
+	if !present {
+		return "", false, false
+	}
+	name = strings.Split(raw, ",")[0]
+	if name == "-" {
+		return "", false, true
+	}
+	if name == "" {
+		return "", false, false
+	}
+	return name, true, false
+}
+
+// checkStrictKind rejects a value whose Go type does not naturally match
+// targetKind, used when Options.WeaklyTypedInput is false to disable the
+// string<->number<->bool coercions ConvertX performs by default.
+func checkStrictKind(value interface{}, targetKind reflect.Kind) error {
+	switch targetKind {
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("strict typing: expected string, got %T", value)
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("strict typing: expected bool, got %T", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch value.(type) {
+		case float64, int, int64, json.Number:
+		default:
+			return fmt.Errorf("strict typing: expected a number, got %T", value)
+		}
+	}
+	return nil
+}
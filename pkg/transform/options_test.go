@@ -0,0 +1,63 @@
+package transform
+
+import "testing"
+
+type TaggedCar struct {
+	Brand string `json:"brand"`
+	Year  int    `json:"year,omitempty"`
+}
+
+type EmbeddedEngine struct {
+	Cylinders int `json:"cylinders"`
+}
+
+type SquashCar struct {
+	EmbeddedEngine
+	Brand string `json:"brand"`
+}
+
+func TestMapToStruct_JSONTag(t *testing.T) {
+	data := map[string]interface{}{"brand": "Toyota", "year": 2020}
+	var c TaggedCar
+	if err := MapToStruct(data, &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Brand != "Toyota" || c.Year != 2020 {
+		t.Fatalf("tag-driven fields not populated correctly: %+v", c)
+	}
+}
+
+func TestMapToStructWithOptions_DisallowUnknownFields(t *testing.T) {
+	data := map[string]interface{}{"brand": "Toyota", "color": "red"}
+	var c TaggedCar
+	opts := DefaultOptions()
+	opts.DisallowUnknownFields = true
+	err := MapToStructWithOptions(data, &c, opts)
+	if err == nil {
+		t.Fatal("expected an error for the unknown 'color' key, got nil")
+	}
+}
+
+func TestMapToStructWithOptions_WeaklyTypedInputDisabled(t *testing.T) {
+	data := map[string]interface{}{"brand": "Toyota", "year": "2020"}
+	var c TaggedCar
+	opts := DefaultOptions()
+	opts.WeaklyTypedInput = false
+	err := MapToStructWithOptions(data, &c, opts)
+	if err == nil {
+		t.Fatal("expected an error for a string year with weak typing disabled, got nil")
+	}
+}
+
+func TestMapToStructWithOptions_Squash(t *testing.T) {
+	data := map[string]interface{}{"brand": "Toyota", "cylinders": 6}
+	var c SquashCar
+	opts := DefaultOptions()
+	opts.Squash = true
+	if err := MapToStructWithOptions(data, &c, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Brand != "Toyota" || c.Cylinders != 6 {
+		t.Fatalf("squashed embedded field not populated correctly: %+v", c)
+	}
+}
@@ -3,6 +3,7 @@ package transform
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 
@@ -32,27 +33,37 @@ func (e *FieldError) IsFieldAffected() bool {
 // Data can be a 'map', 'string', or 'byte slice'. Result should be a pointer to a struct to be filled.
 // This function replaces the old 'DataToStruct' function.
 func Unmarshal(data interface{}, result interface{}) error {
+	return UnmarshalWithOptions(data, result, DefaultOptions())
+}
+
+// UnmarshalWithOptions behaves like Unmarshal but lets the caller customize
+// field resolution and coercion through opts. See Options for details.
+func UnmarshalWithOptions(data interface{}, result interface{}, opts Options) error {
 	switch v := data.(type) {
 	case []byte:
-		return UnmarshalBytes(v, result)
+		return unmarshalBytes(v, result, opts)
 	case string:
-		return UnmarshalString(v, result)
+		return unmarshalString(v, result, opts)
 	case map[string]interface{}:
-		return UnmarshalMap(v, result)
+		return mapToStruct(v, result, opts)
 	case []interface{}:
-		return UnmarshalSlice(v, result)
+		return unmarshalSlice(v, result, opts)
 	case []map[string]interface{}:
-		return UnmarshalSliceOfMaps(v, result)
+		return unmarshalSliceOfMaps(v, result, opts)
 	default:
 		return fmt.Errorf("unsupported data type: %s", reflect.TypeOf(data))
 	}
 }
 
 func UnmarshalBytes(data []byte, result interface{}) error {
+	return unmarshalBytes(data, result, DefaultOptions())
+}
+
+func unmarshalBytes(data []byte, result interface{}, opts Options) error {
 	var raw map[string]interface{}
 	err := json.Unmarshal(data, &raw)
 	if err == nil {
-		return MapToStruct(raw, result)
+		return mapToStruct(raw, result, opts)
 	}
 	var rawSlice []map[string]interface{}
 	err = json.Unmarshal(data, &rawSlice)
@@ -63,16 +74,20 @@ func UnmarshalBytes(data []byte, result interface{}) error {
 	for i, item := range rawSlice {
 		rawInterfaceSlice[i] = item
 	}
-	return Unmarshal(rawInterfaceSlice, result)
+	return unmarshalSlice(rawInterfaceSlice, result, opts)
 }
 
 func UnmarshalString(data string, result interface{}) error {
+	return unmarshalString(data, result, DefaultOptions())
+}
+
+func unmarshalString(data string, result interface{}, opts Options) error {
 	var raw map[string]interface{}
 	err := json.Unmarshal([]byte(data), &raw)
 	if err != nil {
 		return err
 	}
-	return MapToStruct(raw, result)
+	return mapToStruct(raw, result, opts)
 }
 
 func UnmarshalMap(data map[string]interface{}, result interface{}) error {
@@ -80,6 +95,10 @@ func UnmarshalMap(data map[string]interface{}, result interface{}) error {
 }
 
 func UnmarshalSlice(data []interface{}, result interface{}) error {
+	return unmarshalSlice(data, result, DefaultOptions())
+}
+
+func unmarshalSlice(data []interface{}, result interface{}, opts Options) error {
 	resultValue := reflect.ValueOf(result)
 	if resultValue.Kind() != reflect.Ptr || resultValue.Elem().Kind() != reflect.Slice {
 		return fmt.Errorf("result should be a pointer to a slice")
@@ -87,7 +106,7 @@ func UnmarshalSlice(data []interface{}, result interface{}) error {
 	resultValue = resultValue.Elem()
 	for _, item := range data {
 		newItem := reflect.New(resultValue.Type().Elem()).Interface()
-		err := Unmarshal(item, newItem)
+		err := UnmarshalWithOptions(item, newItem, opts)
 		if err != nil {
 			return err
 		}
@@ -98,6 +117,10 @@ func UnmarshalSlice(data []interface{}, result interface{}) error {
 }
 
 func UnmarshalSliceOfMaps(data []map[string]interface{}, result interface{}) error {
+	return unmarshalSliceOfMaps(data, result, DefaultOptions())
+}
+
+func unmarshalSliceOfMaps(data []map[string]interface{}, result interface{}, opts Options) error {
 	resultValue := reflect.ValueOf(result)
 	if resultValue.Kind() != reflect.Ptr || resultValue.Elem().Kind() != reflect.Slice {
 		return fmt.Errorf("result should be a pointer to a slice")
@@ -105,7 +128,7 @@ func UnmarshalSliceOfMaps(data []map[string]interface{}, result interface{}) err
 	resultValue = resultValue.Elem()
 	for _, item := range data {
 		newItem := reflect.New(resultValue.Type().Elem()).Interface()
-		err := Unmarshal(item, newItem)
+		err := mapToStruct(item, newItem, opts)
 		if err != nil {
 			return err
 		}
@@ -115,56 +138,47 @@ func UnmarshalSliceOfMaps(data []map[string]interface{}, result interface{}) err
 	return nil
 }
 
+// MapToStruct converts a map into a struct using DefaultOptions. Use
+// MapToStructWithOptions to customize tag resolution and coercion.
 func MapToStruct(data map[string]interface{}, result interface{}) error {
+	return mapToStruct(data, result, DefaultOptions())
+}
+
+// MapToStructWithOptions behaves like MapToStruct but lets the caller
+// customize field resolution and coercion through opts.
+func MapToStructWithOptions(data map[string]interface{}, result interface{}, opts Options) error {
+	return mapToStruct(data, result, opts)
+}
+
+func mapToStruct(data map[string]interface{}, result interface{}, opts Options) error {
 	resultValue := reflect.ValueOf(result)
 	if resultValue.Kind() != reflect.Ptr || resultValue.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("result should be a pointer to a struct")
 	}
 	resultValue = resultValue.Elem()
+	fields := fieldsFor(resultValue.Type(), opts)
 
 	var multiErr error
 
 	for key, value := range data {
-		field := resultValue.FieldByName(key)
-		if !field.IsValid() {
+		info, ok := fields[key]
+		if !ok {
+			if opts.DisallowUnknownFields || opts.ErrorUnused {
+				multiErr = multierr.Append(multiErr, &FieldError{field: key, err: fmt.Errorf("no matching field for key %q", key), fieldAffected: false})
+			}
 			continue
 		}
+		field := resultValue.FieldByIndex(info.Index)
 		if !field.CanSet() {
 			continue
 		}
 
-		var convertedValue reflect.Value
-		var err error
-		switch field.Kind() {
-		case reflect.String:
-			var strVal string
-			strVal, err = ConvertString(value)
-			convertedValue = reflect.ValueOf(strVal)
-		case reflect.Int:
-			var intVal int
-			intVal, err = ConvertInt(value)
-			convertedValue = reflect.ValueOf(intVal)
-		case reflect.Float64:
-			var floatVal float64
-			floatVal, err = ConvertFloat64(value)
-			convertedValue = reflect.ValueOf(floatVal)
-		case reflect.Bool:
-			var boolVal bool
-			boolVal, err = ConvertBool(value)
-			convertedValue = reflect.ValueOf(boolVal)
-		case reflect.Slice:
-			var sliceVal []interface{}
-			sliceVal, err = ConvertSlice(value)
-			convertedValue = reflect.ValueOf(sliceVal)
-		default:
-			return fmt.Errorf("unsupported field type: %s", field.Type())
-		}
-
+		convertedValue, err := convertValue(value, info.Type, opts, field)
 		if err != nil {
 			multiErr = multierr.Append(multiErr, &FieldError{field: key, err: err, fieldAffected: true})
-		} else {
-			field.Set(convertedValue)
+			continue
 		}
+		field.Set(convertedValue)
 	}
 
 	return multiErr
@@ -184,22 +198,179 @@ func ConvertString(value interface{}) (string, error) {
 }
 
 func ConvertInt(value interface{}) (int, error) {
+	i64, err := convertToInt64(value, 0)
+	if err != nil {
+		return 0, err
+	}
+	if i64 < math.MinInt || i64 > math.MaxInt {
+		return 0, fmt.Errorf("value %v overflows int", value)
+	}
+	return int(i64), nil
+}
+
+func ConvertInt8(value interface{}) (int8, error) {
+	i64, err := convertToInt64(value, 8)
+	if err != nil {
+		return 0, err
+	}
+	if i64 < math.MinInt8 || i64 > math.MaxInt8 {
+		return 0, fmt.Errorf("value %v overflows int8", value)
+	}
+	return int8(i64), nil
+}
+
+func ConvertInt16(value interface{}) (int16, error) {
+	i64, err := convertToInt64(value, 16)
+	if err != nil {
+		return 0, err
+	}
+	if i64 < math.MinInt16 || i64 > math.MaxInt16 {
+		return 0, fmt.Errorf("value %v overflows int16", value)
+	}
+	return int16(i64), nil
+}
+
+func ConvertInt32(value interface{}) (int32, error) {
+	i64, err := convertToInt64(value, 32)
+	if err != nil {
+		return 0, err
+	}
+	if i64 < math.MinInt32 || i64 > math.MaxInt32 {
+		return 0, fmt.Errorf("value %v overflows int32", value)
+	}
+	return int32(i64), nil
+}
+
+func ConvertInt64(value interface{}) (int64, error) {
+	return convertToInt64(value, 64)
+}
+
+func ConvertUint(value interface{}) (uint, error) {
+	u64, err := convertToUint64(value, 0)
+	if err != nil {
+		return 0, err
+	}
+	if u64 > math.MaxUint {
+		return 0, fmt.Errorf("value %v overflows uint", value)
+	}
+	return uint(u64), nil
+}
+
+func ConvertUint8(value interface{}) (uint8, error) {
+	u64, err := convertToUint64(value, 8)
+	if err != nil {
+		return 0, err
+	}
+	if u64 > math.MaxUint8 {
+		return 0, fmt.Errorf("value %v overflows uint8", value)
+	}
+	return uint8(u64), nil
+}
+
+func ConvertUint16(value interface{}) (uint16, error) {
+	u64, err := convertToUint64(value, 16)
+	if err != nil {
+		return 0, err
+	}
+	if u64 > math.MaxUint16 {
+		return 0, fmt.Errorf("value %v overflows uint16", value)
+	}
+	return uint16(u64), nil
+}
+
+func ConvertUint32(value interface{}) (uint32, error) {
+	u64, err := convertToUint64(value, 32)
+	if err != nil {
+		return 0, err
+	}
+	if u64 > math.MaxUint32 {
+		return 0, fmt.Errorf("value %v overflows uint32", value)
+	}
+	return uint32(u64), nil
+}
+
+func ConvertUint64(value interface{}) (uint64, error) {
+	return convertToUint64(value, 64)
+}
+
+// convertToInt64 converts value to an int64, accepting string, int, int64,
+// float64 (which must carry no fractional part, as it's the type JSON
+// numbers unmarshal to), and json.Number. bitSize is forwarded to
+// strconv.ParseInt when value is a string or a type not listed here (parsed
+// from its %v formatting); the listed numeric types ignore it, and range
+// checks for narrower targets are left to the caller.
+func convertToInt64(value interface{}, bitSize int) (int64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, bitSize)
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if math.Trunc(v) != v {
+			return 0, fmt.Errorf("value %v has a fractional part and cannot convert to an integer", v)
+		}
+		return int64(v), nil
+	default:
+		return strconv.ParseInt(fmt.Sprintf("%v", value), 10, bitSize)
+	}
+}
+
+// convertToUint64 mirrors convertToInt64 for unsigned targets.
+func convertToUint64(value interface{}, bitSize int) (uint64, error) {
 	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseUint(v.String(), 10, bitSize)
 	case string:
-		return strconv.Atoi(v)
+		return strconv.ParseUint(v, 10, bitSize)
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("value %v is negative and cannot convert to an unsigned integer", v)
+		}
+		return uint64(v), nil
+	case uint64:
+		return v, nil
 	case float64:
-		return int(v), nil
+		if math.Trunc(v) != v {
+			return 0, fmt.Errorf("value %v has a fractional part and cannot convert to an unsigned integer", v)
+		}
+		if v < 0 {
+			return 0, fmt.Errorf("value %v is negative and cannot convert to an unsigned integer", v)
+		}
+		return uint64(v), nil
 	default:
-		return strconv.Atoi(fmt.Sprintf("%v", value))
+		return strconv.ParseUint(fmt.Sprintf("%v", value), 10, bitSize)
+	}
+}
+
+func ConvertFloat32(value interface{}) (float32, error) {
+	f64, err := convertToFloat64(value)
+	if err != nil {
+		return 0, err
 	}
+	if f64 < -math.MaxFloat32 || f64 > math.MaxFloat32 {
+		return 0, fmt.Errorf("value %v overflows float32", value)
+	}
+	return float32(f64), nil
 }
 
 func ConvertFloat64(value interface{}) (float64, error) {
+	return convertToFloat64(value)
+}
+
+func convertToFloat64(value interface{}) (float64, error) {
 	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
 	case string:
 		return strconv.ParseFloat(v, 64)
 	case int:
 		return float64(v), nil
+	case float64:
+		return v, nil
 	default:
 		return strconv.ParseFloat(fmt.Sprintf("%v", value), 64)
 	}